@@ -0,0 +1,19 @@
+package mediatr
+
+import "context"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID attaches a correlation id to ctx, e.g. to tie together
+// the events persisted by the event outbox with the request that caused
+// them.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation id attached to ctx via
+// WithCorrelationID, or the empty string if none was attached.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
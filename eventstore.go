@@ -0,0 +1,125 @@
+package mediatr
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/The127/mediatr/internal"
+)
+
+// Envelope is a persisted record of an event, as written to an EventStore
+// by SendEvent before the event is dispatched to its handlers.
+type Envelope struct {
+	ID            string
+	EventType     string
+	Payload       []byte
+	CreatedAt     time.Time
+	CorrelationID string
+}
+
+// EventStore makes SendEvent durable: events are appended before dispatch
+// and marked dispatched only once every handler has run successfully. If
+// dispatch fails, the envelope is left pending so DispatchPending can retry
+// it later, turning the mediator into a transactional outbox. With
+// ParallelNoWait, Publish returns before the handlers run, so the envelope
+// is marked dispatched whatever their outcome.
+type EventStore interface {
+	Append(ctx context.Context, envelope Envelope) error
+	LoadPending(ctx context.Context, limit int) ([]Envelope, error)
+	MarkDispatched(ctx context.Context, id string) error
+}
+
+// RegisterEventType makes TEvent's reflect.Type resolvable by name, which
+// DispatchPending needs in order to unmarshal a persisted Envelope's
+// payload back into a concrete event value. If a Transport is configured,
+// it also subscribes to inbound messages for TEvent, since reflect.Type
+// cannot be reconstructed from a name at runtime otherwise.
+func RegisterEventType[TEvent any](m *mediator) {
+	eventType := internal.TypeOf[TEvent]()
+	m.eventTypesByName[eventType.String()] = eventType
+	m.subscribeTransport(eventType)
+}
+
+func newEnvelopeID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generating envelope id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+func (m *mediator) appendEvent(ctx context.Context, evt any, eventType reflect.Type) (Envelope, error) {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("marshalling event %s: %w", eventType.Name(), err)
+	}
+
+	id, err := newEnvelopeID()
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	envelope := Envelope{
+		ID:            id,
+		EventType:     eventType.String(),
+		Payload:       payload,
+		CreatedAt:     time.Now(),
+		CorrelationID: CorrelationID(ctx),
+	}
+
+	if err := m.eventStore.Append(ctx, envelope); err != nil {
+		return Envelope{}, fmt.Errorf("appending event %s: %w", eventType.Name(), err)
+	}
+
+	return envelope, nil
+}
+
+// DispatchPending loads up to limit pending envelopes from the configured
+// EventStore and dispatches each to its registered handlers, publishing it
+// over the Transport too if one is configured, marking it dispatched on
+// success. A limit <= 0 means no limit. It returns nil
+// without doing anything if no EventStore is configured.
+func (m *mediator) DispatchPending(ctx context.Context, limit int) error {
+	if m.eventStore == nil {
+		return nil
+	}
+
+	envelopes, err := m.eventStore.LoadPending(ctx, limit)
+	if err != nil {
+		return fmt.Errorf("loading pending events: %w", err)
+	}
+
+	for _, envelope := range envelopes {
+		eventType, ok := m.eventTypesByName[envelope.EventType]
+		if !ok {
+			return fmt.Errorf("no event type registered for %q, call RegisterEventType for it", envelope.EventType)
+		}
+
+		evtPtr := reflect.New(eventType)
+		if err := json.Unmarshal(envelope.Payload, evtPtr.Interface()); err != nil {
+			return fmt.Errorf("unmarshalling event %q: %w", envelope.EventType, err)
+		}
+		evt := evtPtr.Elem().Interface()
+
+		if err := m.publishStrategy.Publish(ctx, evt, m.eventHandlers[eventType]); err != nil {
+			return fmt.Errorf("dispatching event %q: %w", envelope.EventType, err)
+		}
+
+		if m.transport != nil {
+			if err := m.publishToTransport(ctx, evt, eventType); err != nil {
+				return err
+			}
+		}
+
+		if err := m.eventStore.MarkDispatched(ctx, envelope.ID); err != nil {
+			return fmt.Errorf("marking event %q dispatched: %w", envelope.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Package gorm provides a GORM-backed mediatr.EventStore, suitable as a
+// transactional outbox shared by multiple worker processes.
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/The127/mediatr"
+)
+
+const defaultClaimTimeout = time.Minute
+
+// eventRecord is the GORM model backing the mediatr_events table.
+type eventRecord struct {
+	ID            string `gorm:"primaryKey"`
+	EventType     string `gorm:"index"`
+	Payload       []byte
+	CreatedAt     time.Time
+	CorrelationID string
+	Dispatched    bool       `gorm:"index"`
+	ClaimedAt     *time.Time `gorm:"index"`
+}
+
+func (eventRecord) TableName() string {
+	return "mediatr_events"
+}
+
+// Store is a mediatr.EventStore backed by the mediatr_events table, giving
+// at-least-once delivery across several worker processes calling
+// LoadPending concurrently: it locks the rows it returns with
+// SELECT ... FOR UPDATE SKIP LOCKED and, still under that lock, stamps them
+// with a claim timestamp so no other worker sees them again until
+// claimTimeout has passed. If a worker dies after LoadPending but before
+// MarkDispatched, another worker reclaims and redispatches the event once
+// that timeout elapses.
+type Store struct {
+	db           *gorm.DB
+	claimTimeout time.Duration
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithClaimTimeout overrides the default one-minute claim timeout.
+func WithClaimTimeout(d time.Duration) Option {
+	return func(s *Store) {
+		s.claimTimeout = d
+	}
+}
+
+// NewStore migrates the mediatr_events table on db and returns a Store
+// backed by it.
+func NewStore(db *gorm.DB, opts ...Option) (*Store, error) {
+	s := &Store{db: db, claimTimeout: defaultClaimTimeout}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := db.AutoMigrate(&eventRecord{}); err != nil {
+		return nil, fmt.Errorf("migrating mediatr_events: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *Store) Append(ctx context.Context, envelope mediatr.Envelope) error {
+	record := eventRecord{
+		ID:            envelope.ID,
+		EventType:     envelope.EventType,
+		Payload:       envelope.Payload,
+		CreatedAt:     envelope.CreatedAt,
+		CorrelationID: envelope.CorrelationID,
+	}
+
+	return s.db.WithContext(ctx).Create(&record).Error
+}
+
+func (s *Store) LoadPending(ctx context.Context, limit int) ([]mediatr.Envelope, error) {
+	var records []eventRecord
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched = ? AND (claimed_at IS NULL OR claimed_at < ?)", false, time.Now().Add(-s.claimTimeout)).
+			Order("created_at")
+		if limit > 0 {
+			query = query.Limit(limit)
+		}
+		if err := query.Find(&records).Error; err != nil {
+			return err
+		}
+
+		if len(records) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+
+		// Claim the rows while still holding the lock from above, so they
+		// stay invisible to other workers after this transaction commits
+		// and releases it.
+		return tx.Model(&eventRecord{}).Where("id IN ?", ids).Update("claimed_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading pending events: %w", err)
+	}
+
+	envelopes := make([]mediatr.Envelope, len(records))
+	for i, record := range records {
+		envelopes[i] = mediatr.Envelope{
+			ID:            record.ID,
+			EventType:     record.EventType,
+			Payload:       record.Payload,
+			CreatedAt:     record.CreatedAt,
+			CorrelationID: record.CorrelationID,
+		}
+	}
+
+	return envelopes, nil
+}
+
+func (s *Store) MarkDispatched(ctx context.Context, id string) error {
+	return s.db.WithContext(ctx).
+		Model(&eventRecord{}).
+		Where("id = ?", id).
+		Update("dispatched", true).Error
+}
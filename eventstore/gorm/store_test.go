@@ -0,0 +1,138 @@
+package gorm_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/The127/mediatr"
+	gormstore "github.com/The127/mediatr/eventstore/gorm"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=5000", filepath.Join(t.TempDir(), "mediatr.db"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	// sqlite only allows one writer at a time; force every caller through a
+	// single connection so concurrent LoadPending calls queue on the busy
+	// timeout above instead of racing each other into SQLITE_BUSY.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	return db
+}
+
+func TestStoreMarksEventDispatchedOnSuccess(t *testing.T) {
+	// arrange
+	store, err := gormstore.NewStore(openTestDB(t))
+	require.NoError(t, err)
+
+	envelope := mediatr.Envelope{ID: "evt-1", EventType: "string", Payload: []byte(`"foo"`), CreatedAt: time.Now()}
+	require.NoError(t, store.Append(t.Context(), envelope))
+
+	// act
+	pending, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	require.NoError(t, store.MarkDispatched(t.Context(), envelope.ID))
+
+	// assert
+	pending, err = store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+// TestStoreReclaimsAfterClaimTimeoutElapses exercises the claim-under-lock
+// behaviour documented on Store: a row LoadPending returns is hidden from
+// other callers until claimTimeout elapses, at which point it becomes
+// eligible for redelivery, as if the worker that claimed it had died before
+// calling MarkDispatched.
+func TestStoreReclaimsAfterClaimTimeoutElapses(t *testing.T) {
+	// arrange
+	store, err := gormstore.NewStore(openTestDB(t), gormstore.WithClaimTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+
+	envelope := mediatr.Envelope{ID: "evt-1", EventType: "string", Payload: []byte(`"foo"`), CreatedAt: time.Now()}
+	require.NoError(t, store.Append(t.Context(), envelope))
+
+	// act
+	first, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	immediately, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, immediately, "a freshly claimed row must not be handed out again before the claim times out")
+
+	time.Sleep(20 * time.Millisecond)
+
+	reclaimed, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+
+	// assert
+	require.Len(t, reclaimed, 1)
+	assert.Equal(t, envelope.ID, reclaimed[0].ID)
+}
+
+// TestStoreConcurrentLoadPendingReturnsDisjointSets drives LoadPending from
+// several goroutines at once to check that the claim in LoadPending prevents
+// two callers from claiming the same row. The sqlite dialect emits no
+// FOR UPDATE SKIP LOCKED clause, so here the single connection serializes
+// the transactions and the claimed_at stamp keeps the sets disjoint.
+func TestStoreConcurrentLoadPendingReturnsDisjointSets(t *testing.T) {
+	// arrange
+	store, err := gormstore.NewStore(openTestDB(t))
+	require.NoError(t, err)
+
+	const eventCount = 20
+	for i := 0; i < eventCount; i++ {
+		envelope := mediatr.Envelope{
+			ID:        fmt.Sprintf("evt-%d", i),
+			EventType: "string",
+			Payload:   []byte(`"foo"`),
+			CreatedAt: time.Now(),
+		}
+		require.NoError(t, store.Append(t.Context(), envelope))
+	}
+
+	// act
+	const workerCount = 4
+	var wg sync.WaitGroup
+	results := make([][]mediatr.Envelope, workerCount)
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pending, err := store.LoadPending(context.Background(), 0)
+			require.NoError(t, err)
+			results[i] = pending
+		}(i)
+	}
+	wg.Wait()
+
+	// assert
+	seen := make(map[string]int)
+	for _, pending := range results {
+		for _, envelope := range pending {
+			seen[envelope.ID]++
+		}
+	}
+
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "event %s was claimed by more than one LoadPending call", id)
+	}
+	assert.Len(t, seen, eventCount, "every event should have been claimed exactly once across all callers")
+}
@@ -0,0 +1,70 @@
+// Package eventstore provides mediatr.EventStore implementations.
+package eventstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/The127/mediatr"
+)
+
+type record struct {
+	envelope   mediatr.Envelope
+	dispatched bool
+}
+
+// InMemoryStore is a mediatr.EventStore backed by an in-process map. It is
+// safe for concurrent use and intended for tests and single-process
+// deployments; it does not survive a restart.
+type InMemoryStore struct {
+	mu      sync.Mutex
+	order   []string
+	records map[string]*record
+}
+
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		records: make(map[string]*record),
+	}
+}
+
+func (s *InMemoryStore) Append(ctx context.Context, envelope mediatr.Envelope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order = append(s.order, envelope.ID)
+	s.records[envelope.ID] = &record{envelope: envelope}
+
+	return nil
+}
+
+func (s *InMemoryStore) LoadPending(ctx context.Context, limit int) ([]mediatr.Envelope, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := make([]mediatr.Envelope, 0, len(s.order))
+	for _, id := range s.order {
+		rec, ok := s.records[id]
+		if !ok || rec.dispatched {
+			continue
+		}
+
+		pending = append(pending, rec.envelope)
+		if limit > 0 && len(pending) >= limit {
+			break
+		}
+	}
+
+	return pending, nil
+}
+
+func (s *InMemoryStore) MarkDispatched(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.records[id]; ok {
+		rec.dispatched = true
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package mediatr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/The127/mediatr"
+	"github.com/The127/mediatr/eventstore"
+)
+
+func TestEventStoreMarksEventDispatchedOnSuccess(t *testing.T) {
+	// arrange
+	store := eventstore.NewInMemoryStore()
+	m := mediatr.NewMediator(mediatr.WithEventStore(store))
+	mediatr.RegisterEventType[string](m)
+
+	evtHandlerCalled := false
+	mediatr.RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		evtHandlerCalled = true
+		return nil
+	})
+
+	// act
+	err := mediatr.SendEvent(t.Context(), m, "foo")
+
+	// assert
+	require.NoError(t, err)
+	assert.True(t, evtHandlerCalled)
+
+	pending, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestEventStoreLeavesEventPendingOnHandlerFailure(t *testing.T) {
+	// arrange
+	store := eventstore.NewInMemoryStore()
+	m := mediatr.NewMediator(mediatr.WithEventStore(store))
+	mediatr.RegisterEventType[string](m)
+
+	mediatr.RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		return errors.New("boom")
+	})
+
+	// act
+	err := mediatr.SendEvent(t.Context(), m, "foo")
+	require.Error(t, err)
+
+	// assert
+	pending, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, `"foo"`, string(pending[0].Payload))
+}
+
+type failingTransport struct{}
+
+func (failingTransport) Publish(ctx context.Context, eventType string, payload []byte) error {
+	return errors.New("transport unavailable")
+}
+
+func (failingTransport) Subscribe(eventType string, deliver func(ctx context.Context, payload []byte) error) {
+}
+
+func TestTransportFailureLeavesEventPendingForRedispatch(t *testing.T) {
+	// arrange
+	store := eventstore.NewInMemoryStore()
+	m := mediatr.NewMediator(mediatr.WithEventStore(store), mediatr.WithTransport(failingTransport{}))
+	mediatr.RegisterEventType[string](m)
+
+	evtHandlerCalled := false
+	mediatr.RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		evtHandlerCalled = true
+		return nil
+	})
+
+	// act
+	err := mediatr.SendEvent(t.Context(), m, "foo")
+
+	// assert
+	require.Error(t, err)
+	assert.True(t, evtHandlerCalled)
+
+	pending, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, `"foo"`, string(pending[0].Payload))
+}
+
+func TestDispatchPendingRedeliversFailedEvents(t *testing.T) {
+	// arrange
+	store := eventstore.NewInMemoryStore()
+	m := mediatr.NewMediator(mediatr.WithEventStore(store))
+	mediatr.RegisterEventType[string](m)
+
+	shouldFail := true
+	var calls int
+	mediatr.RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		calls++
+		if shouldFail {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	require.Error(t, mediatr.SendEvent(t.Context(), m, "foo"))
+
+	// act
+	shouldFail = false
+	err := m.DispatchPending(t.Context(), 0)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	pending, err := store.LoadPending(t.Context(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/The127/mediatr/internal"
 )
@@ -12,12 +13,22 @@ import (
 type Mediator interface {
 	Send(ctx context.Context, request any, requestType reflect.Type, responseType reflect.Type) (any, error)
 	SendEvent(ctx context.Context, evt any, eventType reflect.Type) error
+	SendEventWith(ctx context.Context, evt any, eventType reflect.Type, strategy PublishStrategy) error
+	SendStream(ctx context.Context, request any, requestType reflect.Type, responseType reflect.Type) (<-chan any, <-chan error)
+	DispatchPending(ctx context.Context, limit int) error
 }
 
 type mediator struct {
-	handlers      map[reflect.Type]handlerInfo
-	behaviours    []behaviourInfo
-	eventHandlers map[reflect.Type][]eventHandlerInfo
+	handlers         map[reflect.Type]handlerInfo
+	behaviours       []behaviourInfo
+	eventHandlers    map[reflect.Type][]eventHandlerInfo
+	streamHandlers   map[reflect.Type]streamHandlerInfo
+	streamBehaviours []streamBehaviourInfo
+	publishStrategy  PublishStrategy
+	eventStore       EventStore
+	eventTypesByName map[string]reflect.Type
+	transport        Transport
+	instrumentation  Instrumentation
 }
 
 type eventHandlerInfo struct {
@@ -60,12 +71,23 @@ type handlerInfo struct {
 
 type HandlerFunc[TRequest any, TResponse any] func(ctx context.Context, request TRequest) (TResponse, error)
 
-func NewMediator() *mediator {
-	return &mediator{
-		handlers:      make(map[reflect.Type]handlerInfo),
-		behaviours:    make([]behaviourInfo, 0),
-		eventHandlers: make(map[reflect.Type][]eventHandlerInfo),
+func NewMediator(opts ...MediatorOption) *mediator {
+	m := &mediator{
+		handlers:         make(map[reflect.Type]handlerInfo),
+		behaviours:       make([]behaviourInfo, 0),
+		eventHandlers:    make(map[reflect.Type][]eventHandlerInfo),
+		streamHandlers:   make(map[reflect.Type]streamHandlerInfo),
+		streamBehaviours: make([]streamBehaviourInfo, 0),
+		publishStrategy:  SequentialStopOnError{},
+		eventTypesByName: make(map[string]reflect.Type),
+		instrumentation:  NoopInstrumentation{},
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
 }
 
 type BehaviourFunc[TRequest any] func(ctx context.Context, request TRequest, next Next) (any, error)
@@ -97,21 +119,61 @@ func SendEvent[TEvent any](ctx context.Context, m Mediator, evt TEvent) error {
 }
 
 func (m *mediator) SendEvent(ctx context.Context, evt any, eventType reflect.Type) error {
-	eventHandlers, ok := m.eventHandlers[eventType]
-	if !ok {
-		return nil
-	}
+	return m.SendEventWith(ctx, evt, eventType, m.publishStrategy)
+}
 
-	for _, eventHandler := range eventHandlers {
-		err := eventHandler.eventHandlerFunc(ctx, evt)
+func (m *mediator) SendEventWith(ctx context.Context, evt any, eventType reflect.Type, strategy PublishStrategy) error {
+	eventHandlers := m.eventHandlers[eventType]
+
+	var envelope Envelope
+	if m.eventStore != nil {
+		var err error
+		envelope, err = m.appendEvent(ctx, evt, eventType)
 		if err != nil {
 			return err
 		}
 	}
 
+	if len(eventHandlers) > 0 {
+		if err := m.publishInstrumented(ctx, evt, eventType, eventHandlers, strategy); err != nil {
+			return err
+		}
+	}
+
+	if m.transport != nil {
+		if err := m.publishToTransport(ctx, evt, eventType); err != nil {
+			return err
+		}
+	}
+
+	if m.eventStore != nil {
+		if err := m.eventStore.MarkDispatched(ctx, envelope.ID); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// publishInstrumented wraps strategy.Publish with a span and duration
+// metric keyed on eventType's name, mirroring ObservabilityBehaviour for
+// Send. Unlike Send, SendEvent has no response to report, so the span is
+// closed with an empty response type.
+func (m *mediator) publishInstrumented(ctx context.Context, evt any, eventType reflect.Type, eventHandlers []eventHandlerInfo, strategy PublishStrategy) error {
+	name := eventType.Name()
+
+	ctx, endSpan := m.instrumentation.StartSpan(ctx, name)
+
+	start := time.Now()
+	err := strategy.Publish(ctx, evt, eventHandlers)
+	duration := time.Since(start)
+
+	m.instrumentation.RecordDuration(name, duration, err)
+	endSpan("", err)
+
+	return err
+}
+
 func Send[TResponse any](ctx context.Context, m Mediator, request any) (TResponse, error) {
 	requestType := reflect.TypeOf(request)
 	response, err := m.Send(ctx, request, requestType, internal.TypeOf[TResponse]())
@@ -139,7 +201,12 @@ func (m *mediator) Send(ctx context.Context, request any, requestType reflect.Ty
 	var response any
 	var err error
 
+	trace := traceFrom(ctx)
+
 	step = func() (any, error) {
+		if trace != nil {
+			*trace = append(*trace, "handler:"+requestType.Name())
+		}
 		return info.handlerFunc(ctx, request)
 	}
 
@@ -149,6 +216,9 @@ func (m *mediator) Send(ctx context.Context, request any, requestType reflect.Ty
 		behaviour := behaviours[i]
 		prev := step
 		step = func() (any, error) {
+			if trace != nil {
+				*trace = append(*trace, "behaviour:"+behaviour.requestType.String())
+			}
 			return behaviour.behaviourFunc(ctx, request, prev)
 		}
 	}
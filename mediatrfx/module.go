@@ -0,0 +1,79 @@
+// Package mediatrfx wires github.com/The127/mediatr into an Uber fx
+// application. It produces a Mediator singleton and registers every
+// handler, behaviour, and event handler contributed to the
+// "mediatr.handlers", "mediatr.behaviours", and "mediatr.eventHandlers" fx
+// groups instead of requiring manual RegisterHandler calls.
+package mediatrfx
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/The127/mediatr"
+)
+
+// Module provides a mediatr.Mediator singleton and registers every
+// collected handler, behaviour, and event handler against it on startup.
+var Module = fx.Module("mediatr",
+	fx.Provide(newMediator),
+	fx.Invoke(registerHandlers),
+	fx.Invoke(registerBehaviours),
+	fx.Invoke(registerEventHandlers),
+)
+
+func newMediator() mediatr.Mediator {
+	return mediatr.NewMediator()
+}
+
+type handlerRegistrar interface {
+	RegisterHandlerInfo(mediatr.HandlerRegistration)
+}
+
+type behaviourRegistrar interface {
+	RegisterBehaviourInfo(mediatr.BehaviourRegistration)
+}
+
+type eventHandlerRegistrar interface {
+	RegisterEventHandlerInfo(mediatr.EventHandlerRegistration)
+}
+
+type handlerParams struct {
+	fx.In
+
+	Mediator mediatr.Mediator
+	Handlers []mediatr.HandlerRegistration `group:"mediatr.handlers"`
+}
+
+func registerHandlers(params handlerParams) {
+	registrar := params.Mediator.(handlerRegistrar)
+	for _, reg := range params.Handlers {
+		registrar.RegisterHandlerInfo(reg)
+	}
+}
+
+type behaviourParams struct {
+	fx.In
+
+	Mediator   mediatr.Mediator
+	Behaviours []mediatr.BehaviourRegistration `group:"mediatr.behaviours"`
+}
+
+func registerBehaviours(params behaviourParams) {
+	registrar := params.Mediator.(behaviourRegistrar)
+	for _, reg := range params.Behaviours {
+		registrar.RegisterBehaviourInfo(reg)
+	}
+}
+
+type eventHandlerParams struct {
+	fx.In
+
+	Mediator      mediatr.Mediator
+	EventHandlers []mediatr.EventHandlerRegistration `group:"mediatr.eventHandlers"`
+}
+
+func registerEventHandlers(params eventHandlerParams) {
+	registrar := params.Mediator.(eventHandlerRegistrar)
+	for _, reg := range params.EventHandlers {
+		registrar.RegisterEventHandlerInfo(reg)
+	}
+}
@@ -0,0 +1,61 @@
+package mediatr
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Instrumentation records tracing spans and duration metrics for each Send
+// invocation instrumented by ObservabilityBehaviour, and for each SendEvent
+// dispatch when configured via WithInstrumentation. The
+// endSpan func returned by StartSpan takes the response type name and the
+// resulting error so an implementation can attach them as span attributes
+// once the request has actually run. NoopInstrumentation is the default;
+// see mediatr/otel for an OpenTelemetry-backed implementation.
+type Instrumentation interface {
+	StartSpan(ctx context.Context, name string) (context.Context, func(responseType string, err error))
+	RecordDuration(name string, d time.Duration, err error)
+}
+
+// NoopInstrumentation discards every span and metric.
+type NoopInstrumentation struct{}
+
+func (NoopInstrumentation) StartSpan(ctx context.Context, name string) (context.Context, func(responseType string, err error)) {
+	return ctx, func(string, error) {}
+}
+
+func (NoopInstrumentation) RecordDuration(name string, d time.Duration, err error) {}
+
+// ObservabilityBehaviour returns a behaviour that logs, traces, and records
+// duration/outcome metrics for every request it wraps. Register it with
+// RegisterBehaviour[any] to instrument every request type.
+func ObservabilityBehaviour(instr Instrumentation) BehaviourFunc[any] {
+	return func(ctx context.Context, request any, next Next) (any, error) {
+		name := reflect.TypeOf(request).Name()
+
+		ctx, endSpan := instr.StartSpan(ctx, name)
+
+		log := Logger(ctx)
+		start := time.Now()
+
+		response, err := next()
+
+		duration := time.Since(start)
+		instr.RecordDuration(name, duration, err)
+
+		responseType := ""
+		if response != nil {
+			responseType = reflect.TypeOf(response).Name()
+		}
+		endSpan(responseType, err)
+
+		if err != nil {
+			log.Error("request failed", "requestType", name, "duration", duration, "error", err)
+		} else {
+			log.Info("request handled", "requestType", name, "duration", duration)
+		}
+
+		return response, err
+	}
+}
@@ -0,0 +1,147 @@
+package mediatr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingInstrumentation struct {
+	spans         []string
+	spanResponses map[string]string
+	spanErrs      map[string]error
+	durations     map[string]time.Duration
+	errs          map[string]error
+}
+
+func newRecordingInstrumentation() *recordingInstrumentation {
+	return &recordingInstrumentation{
+		spanResponses: make(map[string]string),
+		spanErrs:      make(map[string]error),
+		durations:     make(map[string]time.Duration),
+		errs:          make(map[string]error),
+	}
+}
+
+func (i *recordingInstrumentation) StartSpan(ctx context.Context, name string) (context.Context, func(responseType string, err error)) {
+	i.spans = append(i.spans, name)
+	return ctx, func(responseType string, err error) {
+		i.spanResponses[name] = responseType
+		i.spanErrs[name] = err
+	}
+}
+
+func (i *recordingInstrumentation) RecordDuration(name string, d time.Duration, err error) {
+	i.durations[name] = d
+	i.errs[name] = err
+}
+
+func TestObservabilityBehaviourRecordsSpanAndDuration(t *testing.T) {
+	// arrange
+	instr := newRecordingInstrumentation()
+
+	m := NewMediator()
+	RegisterHandler(m, func(ctx context.Context, request string) (string, error) {
+		return "foo", nil
+	})
+	RegisterBehaviour(m, ObservabilityBehaviour(instr))
+
+	// act
+	response, err := Send[string](t.Context(), m, "bar")
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "foo", response)
+	assert.Equal(t, []string{"string"}, instr.spans)
+	assert.Equal(t, "string", instr.spanResponses["string"])
+	assert.NoError(t, instr.spanErrs["string"])
+	assert.NoError(t, instr.errs["string"])
+}
+
+func TestObservabilityBehaviourRecordsFailure(t *testing.T) {
+	// arrange
+	instr := newRecordingInstrumentation()
+	boom := errors.New("boom")
+
+	m := NewMediator()
+	RegisterHandler(m, func(ctx context.Context, request string) (string, error) {
+		return "", boom
+	})
+	RegisterBehaviour(m, ObservabilityBehaviour(instr))
+
+	// act
+	_, err := Send[string](t.Context(), m, "bar")
+
+	// assert
+	require.ErrorIs(t, err, boom)
+	assert.ErrorIs(t, instr.errs["string"], boom)
+	assert.ErrorIs(t, instr.spanErrs["string"], boom)
+}
+
+func TestWithInstrumentationRecordsSpanAndDurationForSendEvent(t *testing.T) {
+	// arrange
+	instr := newRecordingInstrumentation()
+
+	m := NewMediator(WithInstrumentation(instr))
+	RegisterEventType[string](m)
+
+	evtHandlerCalled := false
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		evtHandlerCalled = true
+		return nil
+	})
+
+	// act
+	err := SendEvent(t.Context(), m, "bar")
+
+	// assert
+	require.NoError(t, err)
+	assert.True(t, evtHandlerCalled)
+	assert.Equal(t, []string{"string"}, instr.spans)
+	assert.NoError(t, instr.spanErrs["string"])
+	assert.NoError(t, instr.errs["string"])
+}
+
+func TestWithInstrumentationRecordsFailureForSendEvent(t *testing.T) {
+	// arrange
+	instr := newRecordingInstrumentation()
+	boom := errors.New("boom")
+
+	m := NewMediator(WithInstrumentation(instr))
+	RegisterEventType[string](m)
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		return boom
+	})
+
+	// act
+	err := SendEvent(t.Context(), m, "bar")
+
+	// assert
+	require.ErrorIs(t, err, boom)
+	assert.ErrorIs(t, instr.errs["string"], boom)
+	assert.ErrorIs(t, instr.spanErrs["string"], boom)
+}
+
+func TestSendWithResultReturnsTraceAndDuration(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	RegisterHandler(m, func(ctx context.Context, request string) (string, error) {
+		return "foo", nil
+	})
+	RegisterBehaviour(m, func(ctx context.Context, request string, next Next) (any, error) {
+		return next()
+	})
+
+	// act
+	result := SendWithResult[string](t.Context(), m, "bar")
+
+	// assert
+	require.NoError(t, result.Err)
+	assert.Equal(t, "foo", result.Response)
+	assert.Equal(t, []string{"behaviour:string", "handler:string"}, result.Trace)
+	assert.GreaterOrEqual(t, result.Duration, time.Duration(0))
+}
@@ -0,0 +1,44 @@
+package mediatr
+
+// MediatorOption configures a mediator created via NewMediator.
+type MediatorOption func(*mediator)
+
+// WithPublishStrategy sets the PublishStrategy used by SendEvent when no
+// per-call strategy is given via SendEventWith. Defaults to
+// SequentialStopOnError.
+func WithPublishStrategy(strategy PublishStrategy) MediatorOption {
+	return func(m *mediator) {
+		m.publishStrategy = strategy
+	}
+}
+
+// WithEventStore turns SendEvent into a transactional outbox: events are
+// appended to store before dispatch and marked dispatched only once every
+// handler has run successfully. See EventStore and DispatchPending.
+func WithEventStore(store EventStore) MediatorOption {
+	return func(m *mediator) {
+		m.eventStore = store
+	}
+}
+
+// WithTransport causes SendEvent to also publish every event over t, and
+// inbound messages delivered by t for a type registered via
+// RegisterEventType to be dispatched to that type's local handlers. See
+// Transport.
+func WithTransport(t Transport) MediatorOption {
+	return func(m *mediator) {
+		m.transport = t
+	}
+}
+
+// WithInstrumentation records a span and duration metric, keyed on the
+// event type name, around every SendEvent/SendEventWith dispatch to local
+// handlers. Unlike Send, which is instrumented per request type via
+// ObservabilityBehaviour, SendEvent has no behaviour pipeline to hook into,
+// so instrumentation is configured here instead. Defaults to
+// NoopInstrumentation.
+func WithInstrumentation(instr Instrumentation) MediatorOption {
+	return func(m *mediator) {
+		m.instrumentation = instr
+	}
+}
@@ -0,0 +1,100 @@
+// Package otel adapts OpenTelemetry tracing and metrics to
+// mediatr.Instrumentation.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Instrumentation adapts an OpenTelemetry tracer and meter to
+// mediatr.Instrumentation: span name is the request type name, and the
+// duration histogram and success/failure counters are recorded per
+// request type.
+type Instrumentation struct {
+	tracer    trace.Tracer
+	durations metric.Float64Histogram
+	successes metric.Int64Counter
+	failures  metric.Int64Counter
+}
+
+// NewInstrumentation creates an Instrumentation using the global
+// OpenTelemetry tracer and meter providers, scoped under name.
+func NewInstrumentation(name string) (*Instrumentation, error) {
+	meter := otel.Meter(name)
+
+	durations, err := meter.Float64Histogram(
+		"mediatr.request.duration",
+		metric.WithDescription("Duration of mediatr Send/SendEvent invocations"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	successes, err := meter.Int64Counter("mediatr.request.success")
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := meter.Int64Counter("mediatr.request.failure")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		tracer:    otel.Tracer(name),
+		durations: durations,
+		successes: successes,
+		failures:  failures,
+	}, nil
+}
+
+func (i *Instrumentation) StartSpan(ctx context.Context, name string) (context.Context, func(responseType string, err error)) {
+	ctx, span := i.tracer.Start(ctx, name)
+
+	return ctx, func(responseType string, err error) {
+		span.SetAttributes(
+			attribute.String("response_type", responseType),
+			attribute.String("error_class", errorClass(err)),
+		)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+}
+
+func (i *Instrumentation) RecordDuration(name string, d time.Duration, err error) {
+	attrs := metric.WithAttributes(
+		attribute.String("request_type", name),
+		attribute.String("error_class", errorClass(err)),
+	)
+
+	i.durations.Record(context.Background(), d.Seconds(), attrs)
+
+	if err != nil {
+		i.failures.Add(context.Background(), 1, attrs)
+		return
+	}
+	i.successes.Add(context.Background(), 1, attrs)
+}
+
+// errorClass returns a coarse classification of err suitable as a metric
+// attribute: "none" if err is nil, otherwise its concrete Go type.
+func errorClass(err error) string {
+	if err == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%T", err)
+}
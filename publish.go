@@ -0,0 +1,122 @@
+package mediatr
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/The127/mediatr/internal"
+)
+
+// MultiError aggregates the errors returned by several event handlers so
+// that callers can still inspect which of them failed, while supporting
+// errors.Is/As via Unwrap.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+func newMultiError(errs []error) error {
+	nonNil := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	return &MultiError{Errors: nonNil}
+}
+
+// PublishStrategy determines how SendEvent invokes the handlers registered
+// for an event type.
+type PublishStrategy interface {
+	Publish(ctx context.Context, evt any, handlers []eventHandlerInfo) error
+}
+
+// SequentialStopOnError runs handlers one after another and returns as soon
+// as one of them fails, leaving the remaining handlers uncalled. This is the
+// default strategy.
+type SequentialStopOnError struct{}
+
+func (SequentialStopOnError) Publish(ctx context.Context, evt any, handlers []eventHandlerInfo) error {
+	for _, handler := range handlers {
+		if err := handler.eventHandlerFunc(ctx, evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SequentialContinueOnError runs handlers one after another, running every
+// handler regardless of earlier failures, and returns a MultiError
+// collecting every error that occurred.
+type SequentialContinueOnError struct{}
+
+func (SequentialContinueOnError) Publish(ctx context.Context, evt any, handlers []eventHandlerInfo) error {
+	errs := make([]error, 0, len(handlers))
+	for _, handler := range handlers {
+		errs = append(errs, handler.eventHandlerFunc(ctx, evt))
+	}
+	return newMultiError(errs)
+}
+
+// ParallelWhenAll runs every handler concurrently and waits for all of them
+// to finish, returning a MultiError collecting every error that occurred.
+type ParallelWhenAll struct{}
+
+func (ParallelWhenAll) Publish(ctx context.Context, evt any, handlers []eventHandlerInfo) error {
+	errs := make([]error, len(handlers))
+
+	var wg sync.WaitGroup
+	for i, handler := range handlers {
+		wg.Add(1)
+		go func(i int, handler eventHandlerInfo) {
+			defer wg.Done()
+			errs[i] = handler.eventHandlerFunc(ctx, evt)
+		}(i, handler)
+	}
+	wg.Wait()
+
+	return newMultiError(errs)
+}
+
+// ParallelNoWait starts every handler concurrently and returns immediately
+// without waiting for any of them to finish. Handler errors cannot be
+// returned to the caller and are logged instead.
+type ParallelNoWait struct{}
+
+func (ParallelNoWait) Publish(ctx context.Context, evt any, handlers []eventHandlerInfo) error {
+	log := Logger(ctx)
+
+	for _, handler := range handlers {
+		go func(handler eventHandlerInfo) {
+			if err := handler.eventHandlerFunc(ctx, evt); err != nil {
+				log.Error("event handler failed", "eventType", handler.eventType.Name(), "error", err)
+			}
+		}(handler)
+	}
+
+	return nil
+}
+
+// SendEventWith dispatches evt like SendEvent, but uses strategy instead of
+// the mediator's configured publish strategy.
+func SendEventWith[TEvent any](ctx context.Context, m Mediator, evt TEvent, strategy PublishStrategy) error {
+	eventType := internal.TypeOf[TEvent]()
+	return m.SendEventWith(ctx, evt, eventType, strategy)
+}
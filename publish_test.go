@@ -0,0 +1,95 @@
+package mediatr
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequentialStopOnErrorStopsAtFirstError(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	var calls []string
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		calls = append(calls, "first")
+		return errors.New("boom")
+	})
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		calls = append(calls, "second")
+		return nil
+	})
+
+	// act
+	err := SendEvent(t.Context(), m, "evt")
+
+	// assert
+	require.Error(t, err)
+	assert.Equal(t, []string{"first"}, calls)
+}
+
+func TestSequentialContinueOnErrorRunsAllAndAggregates(t *testing.T) {
+	// arrange
+	m := NewMediator(WithPublishStrategy(SequentialContinueOnError{}))
+	var calls []string
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		calls = append(calls, "first")
+		return errors.New("boom")
+	})
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		calls = append(calls, "second")
+		return nil
+	})
+
+	// act
+	err := SendEvent(t.Context(), m, "evt")
+
+	// assert
+	require.Error(t, err)
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	var multiErr *MultiError
+	require.ErrorAs(t, err, &multiErr)
+	assert.Len(t, multiErr.Errors, 1)
+}
+
+func TestParallelWhenAllWaitsForEveryHandler(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	var completed atomic.Int32
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		completed.Add(1)
+		return errors.New("first failed")
+	})
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		completed.Add(1)
+		return nil
+	})
+
+	// act
+	err := SendEventWith(t.Context(), m, "evt", ParallelWhenAll{})
+
+	// assert
+	require.Error(t, err)
+	assert.EqualValues(t, 2, completed.Load())
+}
+
+func TestParallelNoWaitReturnsImmediately(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	started := make(chan struct{})
+	RegisterEventHandler(m, func(ctx context.Context, evt string) error {
+		close(started)
+		return nil
+	})
+
+	// act
+	err := SendEventWith(t.Context(), m, "evt", ParallelNoWait{})
+
+	// assert
+	require.NoError(t, err)
+	<-started
+}
@@ -0,0 +1,88 @@
+package mediatr
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/The127/mediatr/internal"
+)
+
+// HandlerRegistration is a type-erased handler registration produced by
+// AsHandler. It exists for callers that collect handlers through
+// non-generic mechanisms, such as dependency-injection groups, where the
+// generic RegisterHandler cannot be used directly.
+type HandlerRegistration struct {
+	RequestType  reflect.Type
+	ResponseType reflect.Type
+	HandlerFunc  func(ctx context.Context, request any) (any, error)
+}
+
+func AsHandler[TRequest any, TResponse any](handler HandlerFunc[TRequest, TResponse]) HandlerRegistration {
+	return HandlerRegistration{
+		RequestType:  internal.TypeOf[TRequest](),
+		ResponseType: internal.TypeOf[TResponse](),
+		HandlerFunc: func(ctx context.Context, request any) (any, error) {
+			return handler(ctx, request.(TRequest))
+		},
+	}
+}
+
+// RegisterHandlerInfo registers a type-erased handler produced by AsHandler.
+func (m *mediator) RegisterHandlerInfo(reg HandlerRegistration) {
+	m.handlers[reg.RequestType] = handlerInfo{
+		requestType:  reg.RequestType,
+		responseType: reg.ResponseType,
+		handlerFunc:  reg.HandlerFunc,
+	}
+}
+
+// BehaviourRegistration is the type-erased counterpart of
+// AsHandler for behaviours, see HandlerRegistration.
+type BehaviourRegistration struct {
+	RequestType   reflect.Type
+	BehaviourFunc func(ctx context.Context, request any, next Next) (any, error)
+}
+
+func AsBehaviour[TRequest any](behaviour BehaviourFunc[TRequest]) BehaviourRegistration {
+	return BehaviourRegistration{
+		RequestType: internal.TypeOf[TRequest](),
+		BehaviourFunc: func(ctx context.Context, request any, next Next) (any, error) {
+			return behaviour(ctx, request.(TRequest), next)
+		},
+	}
+}
+
+// RegisterBehaviourInfo registers a type-erased behaviour produced by
+// AsBehaviour.
+func (m *mediator) RegisterBehaviourInfo(reg BehaviourRegistration) {
+	m.behaviours = append(m.behaviours, behaviourInfo{
+		requestType:   reg.RequestType,
+		behaviourFunc: reg.BehaviourFunc,
+	})
+}
+
+// EventHandlerRegistration is the type-erased counterpart of
+// AsHandler for event handlers, see HandlerRegistration.
+type EventHandlerRegistration struct {
+	EventType        reflect.Type
+	EventHandlerFunc func(ctx context.Context, evt any) error
+}
+
+func AsEventHandler[TEvent any](handler EventHandlerFunc[TEvent]) EventHandlerRegistration {
+	return EventHandlerRegistration{
+		EventType: internal.TypeOf[TEvent](),
+		EventHandlerFunc: func(ctx context.Context, evt any) error {
+			return handler(ctx, evt.(TEvent))
+		},
+	}
+}
+
+// RegisterEventHandlerInfo registers a type-erased event handler produced by
+// AsEventHandler.
+func (m *mediator) RegisterEventHandlerInfo(reg EventHandlerRegistration) {
+	eventHandlers := m.eventHandlers[reg.EventType]
+	m.eventHandlers[reg.EventType] = append(eventHandlers, eventHandlerInfo{
+		eventType:        reg.EventType,
+		eventHandlerFunc: reg.EventHandlerFunc,
+	})
+}
@@ -0,0 +1,63 @@
+package mediatr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsHandlerRegistersTypeErasedHandler(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	m.RegisterHandlerInfo(AsHandler(func(ctx context.Context, request string) (string, error) {
+		return "foo", nil
+	}))
+
+	// act
+	response, err := Send[string](t.Context(), m, "bar")
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "foo", response)
+}
+
+func TestAsBehaviourRegistersTypeErasedBehaviour(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	m.RegisterHandlerInfo(AsHandler(func(ctx context.Context, request string) (string, error) {
+		return "foo", nil
+	}))
+
+	behaviourCalled := false
+	m.RegisterBehaviourInfo(AsBehaviour(func(ctx context.Context, request string, next Next) (any, error) {
+		behaviourCalled = true
+		return next()
+	}))
+
+	// act
+	response, err := Send[string](t.Context(), m, "bar")
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "foo", response)
+	assert.True(t, behaviourCalled)
+}
+
+func TestAsEventHandlerRegistersTypeErasedEventHandler(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	evtHandlerCalled := false
+	m.RegisterEventHandlerInfo(AsEventHandler(func(ctx context.Context, evt string) error {
+		evtHandlerCalled = true
+		return nil
+	}))
+
+	// act
+	err := SendEvent(t.Context(), m, "foo")
+
+	// assert
+	require.NoError(t, err)
+	assert.True(t, evtHandlerCalled)
+}
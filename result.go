@@ -0,0 +1,44 @@
+package mediatr
+
+import (
+	"context"
+	"time"
+)
+
+type traceKey struct{}
+
+func withTrace(ctx context.Context, trace *[]string) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+func traceFrom(ctx context.Context) *[]string {
+	trace, _ := ctx.Value(traceKey{}).(*[]string)
+	return trace
+}
+
+// Result carries the outcome of a Send invocation together with its
+// duration and the behaviour/handler pipeline that executed, so tests and
+// debuggers can inspect what ran without re-instrumenting each behaviour.
+type Result[TResponse any] struct {
+	Response TResponse
+	Duration time.Duration
+	Err      error
+	Trace    []string
+}
+
+// SendWithResult behaves like Send but returns a Result instead of
+// separate response/error values.
+func SendWithResult[TResponse any](ctx context.Context, m Mediator, request any) Result[TResponse] {
+	trace := make([]string, 0)
+	ctx = withTrace(ctx, &trace)
+
+	start := time.Now()
+	response, err := Send[TResponse](ctx, m, request)
+
+	return Result[TResponse]{
+		Response: response,
+		Duration: time.Since(start),
+		Err:      err,
+		Trace:    trace,
+	}
+}
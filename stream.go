@@ -0,0 +1,162 @@
+package mediatr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/The127/mediatr/internal"
+)
+
+type streamHandlerInfo struct {
+	requestType       reflect.Type
+	responseType      reflect.Type
+	streamHandlerFunc func(ctx context.Context, request any, yield func(any) error) error
+}
+
+// StreamHandlerFunc handles a request by calling yield once per response
+// item. It should return when the stream is exhausted, or propagate the
+// error returned by yield (which signals that the consumer stopped reading,
+// typically because ctx was cancelled).
+type StreamHandlerFunc[TRequest any, TResponse any] func(ctx context.Context, request TRequest, yield func(TResponse) error) error
+
+func RegisterStreamHandler[TRequest any, TResponse any](m *mediator, handler StreamHandlerFunc[TRequest, TResponse]) {
+	m.streamHandlers[internal.TypeOf[TRequest]()] = streamHandlerInfo{
+		requestType:  internal.TypeOf[TRequest](),
+		responseType: internal.TypeOf[TResponse](),
+		streamHandlerFunc: func(ctx context.Context, request any, yield func(any) error) error {
+			return handler(ctx, request.(TRequest), func(response TResponse) error {
+				return yield(response)
+			})
+		},
+	}
+}
+
+// StreamNext invokes the rest of the streaming pipeline, passing yield down
+// as the function the inner stage (the next behaviour, or the handler) must
+// call once per item it produces.
+type StreamNext func(yield func(any) error) error
+
+type streamBehaviourInfo struct {
+	requestType         reflect.Type
+	streamBehaviourFunc func(ctx context.Context, request any, next StreamNext, yield func(any) error) error
+}
+
+// StreamBehaviourFunc wraps a streaming request. It must call next with a
+// yield func of its own in order to receive the items produced further down
+// the chain, and forward each one (after e.g. logging or metrics) on to
+// yield, which is the sink further up the chain - the next behaviour, or
+// the consumer if this is the outermost behaviour.
+type StreamBehaviourFunc[TRequest any] func(ctx context.Context, request TRequest, next StreamNext, yield func(any) error) error
+
+func RegisterStreamBehaviour[TRequest any](m *mediator, behaviour StreamBehaviourFunc[TRequest]) {
+	requestType := internal.TypeOf[TRequest]()
+
+	m.streamBehaviours = append(m.streamBehaviours, streamBehaviourInfo{
+		requestType: requestType,
+		streamBehaviourFunc: func(ctx context.Context, request any, next StreamNext, yield func(any) error) error {
+			return behaviour(ctx, request.(TRequest), next, yield)
+		},
+	})
+}
+
+// SendStream dispatches request to the registered stream handler and returns
+// a channel of typed responses alongside an error channel. Both channels are
+// closed once the handler finishes or the ctx is cancelled, whichever happens
+// first, and the goroutines behind them exit. A consumer that stops reading
+// without cancelling ctx leaves those goroutines blocked on the next send.
+func SendStream[TResponse any](ctx context.Context, m Mediator, request any) (<-chan TResponse, <-chan error) {
+	requestType := reflect.TypeOf(request)
+	rawItems, rawErr := m.SendStream(ctx, request, requestType, internal.TypeOf[TResponse]())
+
+	items := make(chan TResponse)
+	go func() {
+		defer close(items)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-rawItems:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case items <- item.(TResponse):
+				}
+			}
+		}
+	}()
+
+	return items, rawErr
+}
+
+func (m *mediator) SendStream(ctx context.Context, request any, requestType reflect.Type, responseType reflect.Type) (<-chan any, <-chan error) {
+	log := Logger(ctx)
+
+	items := make(chan any)
+	errs := make(chan error, 1)
+
+	info, ok := m.streamHandlers[requestType]
+	if !ok {
+		log.Error("no stream handler registered", "requestType", requestType.Name())
+		errs <- fmt.Errorf("no stream handler registered for request type %s", requestType.Name())
+		close(items)
+		close(errs)
+		return items, errs
+	}
+
+	if info.responseType != responseType {
+		log.Error("wrong response type", "responseType", responseType.Name(), "expected", info.responseType.Name())
+		errs <- fmt.Errorf("wrong response type %s was used for request %s, expected response type %s", responseType.Name(), requestType.Name(), info.responseType.Name())
+		close(items)
+		close(errs)
+		return items, errs
+	}
+
+	var step StreamNext
+	step = func(yield func(any) error) error {
+		return info.streamHandlerFunc(ctx, request, yield)
+	}
+
+	behaviours := m.getStreamBehaviours(requestType)
+	for i := len(behaviours) - 1; i >= 0; i-- {
+		behaviour := behaviours[i]
+		prev := step
+		step = func(yield func(any) error) error {
+			return behaviour.streamBehaviourFunc(ctx, request, prev, yield)
+		}
+	}
+
+	go func() {
+		defer close(items)
+		defer close(errs)
+
+		err := step(func(item any) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case items <- item:
+				return nil
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return items, errs
+}
+
+func (m *mediator) getStreamBehaviours(requestType reflect.Type) []streamBehaviourInfo {
+	result := make([]streamBehaviourInfo, 0)
+
+	for _, behaviour := range m.streamBehaviours {
+		if requestType.AssignableTo(behaviour.requestType) {
+			result = append(result, behaviour)
+		}
+	}
+
+	return result
+}
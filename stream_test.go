@@ -0,0 +1,100 @@
+package mediatr
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamHandlerGetsCalled(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	RegisterStreamHandler(m, func(ctx context.Context, request string, yield func(string) error) error {
+		for _, item := range []string{"foo", "bar", "baz"} {
+			if err := yield(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// act
+	items, errs := SendStream[string](t.Context(), m, "ignored")
+
+	var received []string
+	for item := range items {
+		received = append(received, item)
+	}
+
+	// assert
+	require.NoError(t, <-errs)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, received)
+}
+
+func TestStreamBehaviourForwardsItemsToTheConsumer(t *testing.T) {
+	// arrange
+	m := NewMediator()
+	RegisterStreamHandler(m, func(ctx context.Context, request string, yield func(string) error) error {
+		for _, item := range []string{"foo", "bar", "baz"} {
+			if err := yield(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var seen []any
+	RegisterStreamBehaviour(m, func(ctx context.Context, request string, next StreamNext, yield func(any) error) error {
+		return next(func(item any) error {
+			seen = append(seen, item)
+			return yield(item)
+		})
+	})
+
+	// act
+	items, errs := SendStream[string](t.Context(), m, "ignored")
+
+	var received []string
+	for item := range items {
+		received = append(received, item)
+	}
+
+	// assert
+	require.NoError(t, <-errs)
+	assert.Equal(t, []any{"foo", "bar", "baz"}, seen)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, received)
+}
+
+func TestStreamNoGoroutineLeakWhenConsumerCancelsEarly(t *testing.T) {
+	// arrange
+	before := runtime.NumGoroutine()
+
+	m := NewMediator()
+	RegisterStreamHandler(m, func(ctx context.Context, request string, yield func(int) error) error {
+		for i := 0; ; i++ {
+			if err := yield(i); err != nil {
+				return err
+			}
+		}
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	// act
+	items, _ := SendStream[int](ctx, m, "ignored")
+	<-items
+	cancel()
+
+	// drain until the channel closes so we observe the goroutines winding down
+	for range items {
+	}
+
+	// assert
+	assert.Eventually(t, func() bool {
+		return runtime.NumGoroutine() <= before+1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,53 @@
+package mediatr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Transport lets events cross process boundaries. When configured via
+// WithTransport, SendEvent publishes every event to it in addition to
+// dispatching to local handlers, and messages it delivers to a
+// subscription (registered for every type passed to RegisterEventType) are
+// decoded and dispatched to local handlers with the mediator's publish
+// strategy. Inbound events are not appended to the EventStore, not
+// instrumented, and not published back to the transport.
+type Transport interface {
+	Publish(ctx context.Context, eventType string, payload []byte) error
+	Subscribe(eventType string, deliver func(ctx context.Context, payload []byte) error)
+}
+
+func (m *mediator) publishToTransport(ctx context.Context, evt any, eventType reflect.Type) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("marshalling event %s for transport: %w", eventType.Name(), err)
+	}
+
+	if err := m.transport.Publish(ctx, eventType.String(), payload); err != nil {
+		return fmt.Errorf("publishing event %s to transport: %w", eventType.Name(), err)
+	}
+
+	return nil
+}
+
+// subscribeTransport registers a Transport subscription that decodes an
+// inbound message back into eventType and dispatches it to the handlers
+// registered locally for that type.
+func (m *mediator) subscribeTransport(eventType reflect.Type) {
+	if m.transport == nil {
+		return
+	}
+
+	name := eventType.String()
+	m.transport.Subscribe(name, func(ctx context.Context, payload []byte) error {
+		evtPtr := reflect.New(eventType)
+		if err := json.Unmarshal(payload, evtPtr.Interface()); err != nil {
+			return fmt.Errorf("unmarshalling event %q: %w", name, err)
+		}
+		evt := evtPtr.Elem().Interface()
+
+		return m.publishStrategy.Publish(ctx, evt, m.eventHandlers[eventType])
+	})
+}
@@ -0,0 +1,167 @@
+// Package kafka provides a mediatr.Transport backed by Kafka, using
+// IBM/sarama.
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+const typeHeaderKey = "mediatr-event-type"
+
+// Codec encodes and decodes event payloads for transit over Kafka. The
+// default, JSONCodec, passes payloads through unchanged since mediatr
+// already encodes events as JSON.
+type Codec interface {
+	Encode(payload []byte) ([]byte, error)
+	Decode(data []byte) ([]byte, error)
+}
+
+// JSONCodec is the default Codec.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(payload []byte) ([]byte, error) { return payload, nil }
+func (JSONCodec) Decode(data []byte) ([]byte, error)    { return data, nil }
+
+// Transport is a mediatr.Transport that publishes events to, and consumes
+// them from, a single Kafka topic. Each message carries the fully
+// qualified event type name in the typeHeaderKey header, which the
+// receiver uses to route the message to the matching subscriber since a
+// reflect.Type cannot be reconstructed from a name at runtime.
+type Transport struct {
+	topic    string
+	producer sarama.SyncProducer
+	codec    Codec
+
+	mu          sync.RWMutex
+	subscribers map[string]func(ctx context.Context, payload []byte) error
+}
+
+type Option func(*Transport)
+
+// WithCodec overrides the default JSONCodec.
+func WithCodec(codec Codec) Option {
+	return func(t *Transport) {
+		t.codec = codec
+	}
+}
+
+func NewTransport(producer sarama.SyncProducer, topic string, opts ...Option) *Transport {
+	t := &Transport{
+		topic:       topic,
+		producer:    producer,
+		codec:       JSONCodec{},
+		subscribers: make(map[string]func(ctx context.Context, payload []byte) error),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+func (t *Transport) Publish(ctx context.Context, eventType string, payload []byte) error {
+	encoded, err := t.codec.Encode(payload)
+	if err != nil {
+		return fmt.Errorf("encoding event %q: %w", eventType, err)
+	}
+
+	_, _, err = t.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: t.topic,
+		Value: sarama.ByteEncoder(encoded),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(typeHeaderKey), Value: []byte(eventType)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("publishing event %q to topic %q: %w", eventType, t.topic, err)
+	}
+
+	return nil
+}
+
+func (t *Transport) Subscribe(eventType string, deliver func(ctx context.Context, payload []byte) error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subscribers[eventType] = deliver
+}
+
+// Consume runs group's consumer loop against t's topic until ctx is
+// cancelled, routing every message to the subscriber registered for the
+// type name in its typeHeaderKey header. Messages for unsubscribed types
+// are acknowledged and dropped.
+func (t *Transport) Consume(ctx context.Context, group sarama.ConsumerGroup) error {
+	handler := &consumerGroupHandler{transport: t}
+
+	for {
+		if err := group.Consume(ctx, []string{t.topic}, handler); err != nil {
+			return fmt.Errorf("consuming topic %q: %w", t.topic, err)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+type consumerGroupHandler struct {
+	transport *Transport
+}
+
+func (h *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if err := h.transport.handleMessage(session.Context(), msg); err != nil {
+				return err
+			}
+
+			session.MarkMessage(msg, "")
+
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (t *Transport) handleMessage(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	eventType := headerValue(msg.Headers, typeHeaderKey)
+	if eventType == "" {
+		return fmt.Errorf("message on topic %q missing %s header", t.topic, typeHeaderKey)
+	}
+
+	t.mu.RLock()
+	deliver, ok := t.subscribers[eventType]
+	t.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	payload, err := t.codec.Decode(msg.Value)
+	if err != nil {
+		return fmt.Errorf("decoding event %q: %w", eventType, err)
+	}
+
+	return deliver(ctx, payload)
+}
+
+func headerValue(headers []*sarama.RecordHeader, key string) string {
+	for _, header := range headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
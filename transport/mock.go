@@ -0,0 +1,43 @@
+// Package transport provides mediatr.Transport implementations.
+package transport
+
+import (
+	"context"
+	"sync"
+)
+
+// MockTransport is an in-process mediatr.Transport that delivers published
+// events directly to its subscribers, without a network hop. It is
+// intended for unit-testing event handlers without a real message broker,
+// analogous to sarama's MockResponses.
+type MockTransport struct {
+	mu          sync.RWMutex
+	subscribers map[string][]func(ctx context.Context, payload []byte) error
+}
+
+func NewMockTransport() *MockTransport {
+	return &MockTransport{
+		subscribers: make(map[string][]func(ctx context.Context, payload []byte) error),
+	}
+}
+
+func (t *MockTransport) Publish(ctx context.Context, eventType string, payload []byte) error {
+	t.mu.RLock()
+	subscribers := t.subscribers[eventType]
+	t.mu.RUnlock()
+
+	for _, deliver := range subscribers {
+		if err := deliver(ctx, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *MockTransport) Subscribe(eventType string, deliver func(ctx context.Context, payload []byte) error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subscribers[eventType] = append(t.subscribers[eventType], deliver)
+}
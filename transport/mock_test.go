@@ -0,0 +1,52 @@
+package transport_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/The127/mediatr"
+	"github.com/The127/mediatr/transport"
+)
+
+func TestMockTransportDeliversPublishedEventsToSubscribers(t *testing.T) {
+	// arrange
+	tp := transport.NewMockTransport()
+	publisher := mediatr.NewMediator(mediatr.WithTransport(tp))
+	consumer := mediatr.NewMediator(mediatr.WithTransport(tp))
+
+	received := ""
+	mediatr.RegisterEventHandler(consumer, func(ctx context.Context, evt string) error {
+		received = evt
+		return nil
+	})
+	mediatr.RegisterEventType[string](consumer)
+
+	// act
+	err := mediatr.SendEvent(t.Context(), publisher, "foo")
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "foo", received)
+}
+
+func TestMockTransportIgnoresUnsubscribedTypes(t *testing.T) {
+	// arrange
+	tp := transport.NewMockTransport()
+
+	// act
+	err := tp.Publish(t.Context(), "mediatr_test.unknownEvent", mustJSON(t, "foo"))
+
+	// assert
+	require.NoError(t, err)
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}